@@ -0,0 +1,93 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// AtomicArray implements a copy-on-write slice snapshot with atomic
+// semantics. Readers call Get (or GetAt/Length) to obtain a consistent
+// view of the slice without coordinating with writers; writers always
+// publish a freshly allocated slice rather than mutating one in place,
+// mirroring the node/partition table pattern used by the Java client.
+type AtomicArray struct {
+	val atomic.Value
+
+	// writeMutex serializes all writers (Set and CompareAndSet) so that a
+	// plain Set can't interleave between CompareAndSet's load and store;
+	// reads remain lock-free.
+	writeMutex sync.Mutex
+}
+
+// NewAtomicArray generates a new AtomicArray instance.
+func NewAtomicArray(arr []interface{}) *AtomicArray {
+	aa := &AtomicArray{}
+	if arr == nil {
+		arr = []interface{}{}
+	}
+	aa.val.Store(arr)
+	return aa
+}
+
+// Get atomically retrieves the current slice snapshot.
+func (aa *AtomicArray) Get() []interface{} {
+	return aa.val.Load().([]interface{})
+}
+
+// Set atomically publishes a new slice snapshot.
+func (aa *AtomicArray) Set(arr []interface{}) {
+	aa.writeMutex.Lock()
+	defer aa.writeMutex.Unlock()
+
+	if arr == nil {
+		arr = []interface{}{}
+	}
+	aa.val.Store(arr)
+}
+
+// CompareAndSet atomically publishes the new slice if the current slice == the old slice.
+// Returns true if the expectation was met.
+func (aa *AtomicArray) CompareAndSet(old []interface{}, new []interface{}) bool {
+	aa.writeMutex.Lock()
+	defer aa.writeMutex.Unlock()
+
+	curr := aa.val.Load().([]interface{})
+	if len(curr) != len(old) {
+		return false
+	}
+	for i := range curr {
+		if curr[i] != old[i] {
+			return false
+		}
+	}
+
+	if new == nil {
+		new = []interface{}{}
+	}
+	aa.val.Store(new)
+	return true
+}
+
+// Length atomically returns the length of the current slice snapshot.
+func (aa *AtomicArray) Length() int {
+	return len(aa.Get())
+}
+
+// GetAt atomically retrieves the element at the given index of the current slice snapshot.
+func (aa *AtomicArray) GetAt(i int) interface{} {
+	return aa.Get()[i]
+}
@@ -0,0 +1,98 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomicArray(t *testing.T) {
+	initial := []interface{}{"a", "b", "c"}
+	aa := NewAtomicArray(initial)
+
+	if aa.Length() != 3 {
+		t.Error("Length should return 3")
+	}
+
+	if aa.GetAt(1) != "b" {
+		t.Error("GetAt(1) should return \"b\"")
+	}
+
+	updated := []interface{}{"a", "b", "c", "d"}
+	if !aa.CompareAndSet(initial, updated) {
+		t.Error("CompareAndSet should succeed when expectation is met")
+	}
+
+	if aa.Length() != 4 {
+		t.Error("Length should return 4 after CompareAndSet")
+	}
+
+	if aa.CompareAndSet(initial, updated) {
+		t.Error("CompareAndSet should fail when expectation is not met")
+	}
+
+	snapshot := aa.Get()
+	aa.Set([]interface{}{"x"})
+
+	if len(snapshot) != 4 {
+		t.Error("previously retrieved snapshot should remain unaffected by a later Set")
+	}
+
+	if aa.Length() != 1 {
+		t.Error("Length should return 1 after Set")
+	}
+}
+
+func TestNewAtomicArrayNil(t *testing.T) {
+	aa := NewAtomicArray(nil)
+
+	if aa.Length() != 0 {
+		t.Error("Length should return 0 for a nil initial slice")
+	}
+}
+
+// TestAtomicArrayCompareAndSetRetryLoopUnderContention exercises
+// CompareAndSet concurrently the way the cluster tend loop would: many
+// goroutines racing a CAS retry loop to each append their own element.
+// Every append is reflected only if CompareAndSet's load, comparison and
+// store are serialized by writeMutex, so that two calls can't both succeed
+// against the same old slice.
+func TestAtomicArrayCompareAndSetRetryLoopUnderContention(t *testing.T) {
+	const goroutines = 50
+
+	aa := NewAtomicArray(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		g := g
+		go func() {
+			defer wg.Done()
+			for {
+				old := aa.Get()
+				updated := append(append([]interface{}{}, old...), g)
+				if aa.CompareAndSet(old, updated) {
+					break
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if aa.Length() != goroutines {
+		t.Errorf("expected %d appended elements to be reflected, got %d", goroutines, aa.Length())
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"sync/atomic"
+)
+
+// AtomicBool implements a bool value with atomic semantics.
+// val holds 1 for true and 0 for false.
+type AtomicBool struct {
+	val int32
+}
+
+// NewAtomicBool generates a new AtomicBool instance.
+func NewAtomicBool(value bool) *AtomicBool {
+	ab := &AtomicBool{}
+	ab.Set(value)
+	return ab
+}
+
+// Get atomically retrieves the current value.
+func (ab *AtomicBool) Get() bool {
+	return atomic.LoadInt32(&ab.val) != 0
+}
+
+// Set atomically sets the current value to the given value and returns the old value.
+func (ab *AtomicBool) Set(newValue bool) bool {
+	var new32 int32
+	if newValue {
+		new32 = 1
+	}
+	old := atomic.SwapInt32(&ab.val, new32)
+	return old != 0
+}
+
+// CompareAndToggle atomically flips the value to !expect if the current value == expect.
+// Returns true if the expectation was met and the value was toggled.
+func (ab *AtomicBool) CompareAndToggle(expect bool) bool {
+	var old32, new32 int32
+	if expect {
+		old32 = 1
+	} else {
+		new32 = 1
+	}
+	return atomic.CompareAndSwapInt32(&ab.val, old32, new32)
+}
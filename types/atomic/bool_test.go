@@ -0,0 +1,47 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"testing"
+)
+
+func TestAtomicBool(t *testing.T) {
+	ab := NewAtomicBool(false)
+
+	if ab.Get() {
+		t.Error("Get should return false")
+	}
+
+	if ab.Set(true) {
+		t.Error("Set should return the old value (false)")
+	}
+
+	if !ab.Get() {
+		t.Error("Get should return true")
+	}
+
+	if !ab.CompareAndToggle(true) {
+		t.Error("CompareAndToggle should succeed when expectation is met")
+	}
+
+	if ab.Get() {
+		t.Error("Get should return false after toggling")
+	}
+
+	if ab.CompareAndToggle(true) {
+		t.Error("CompareAndToggle should fail when expectation is not met")
+	}
+}
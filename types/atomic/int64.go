@@ -0,0 +1,85 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"sync/atomic"
+)
+
+// AtomicInt64 implements an int64 value with atomic semantics.
+// It mirrors AtomicInt, but is sized for counters and timestamps that
+// would otherwise overflow a 32-bit int on 32-bit builds.
+type AtomicInt64 struct {
+	val int64
+}
+
+// NewAtomicInt64 generates a new AtomicInt64 instance.
+func NewAtomicInt64(value int64) *AtomicInt64 {
+	return &AtomicInt64{
+		val: value,
+	}
+}
+
+// AddAndGet atomically adds the given value to the current value.
+func (ai *AtomicInt64) AddAndGet(delta int64) int64 {
+	return atomic.AddInt64(&ai.val, delta)
+}
+
+// CompareAndSet atomically sets the value to the given updated value if the current value == expected value.
+// Returns true if the expectation was met
+func (ai *AtomicInt64) CompareAndSet(expect int64, update int64) bool {
+	return atomic.CompareAndSwapInt64(&ai.val, expect, update)
+}
+
+// DecrementAndGet atomically decrements current value by one and returns the result.
+func (ai *AtomicInt64) DecrementAndGet() int64 {
+	return atomic.AddInt64(&ai.val, -1)
+}
+
+// Get atomically retrieves the current value.
+func (ai *AtomicInt64) Get() int64 {
+	return atomic.LoadInt64(&ai.val)
+}
+
+// GetAndAdd atomically adds the given delta to the current value and returns the previous value.
+func (ai *AtomicInt64) GetAndAdd(delta int64) int64 {
+	newVal := atomic.AddInt64(&ai.val, delta)
+	return newVal - delta
+}
+
+// GetAndDecrement atomically decrements the current value by one and returns the previous value.
+func (ai *AtomicInt64) GetAndDecrement() int64 {
+	return ai.GetAndAdd(-1)
+}
+
+// GetAndIncrement atomically increments current value by one and returns the previous value.
+func (ai *AtomicInt64) GetAndIncrement() int64 {
+	return ai.GetAndAdd(1)
+}
+
+// GetAndSet atomically sets current value to the given value and returns the old value.
+func (ai *AtomicInt64) GetAndSet(newValue int64) int64 {
+	return atomic.SwapInt64(&ai.val, newValue)
+}
+
+// IncrementAndGet atomically increments current value by one and returns the result.
+func (ai *AtomicInt64) IncrementAndGet() int64 {
+	return atomic.AddInt64(&ai.val, 1)
+}
+
+// Set atomically sets current value to the given value.
+func (ai *AtomicInt64) Set(newValue int64) {
+	atomic.StoreInt64(&ai.val, newValue)
+}
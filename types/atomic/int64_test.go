@@ -0,0 +1,55 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"testing"
+)
+
+func TestAtomicInt64(t *testing.T) {
+	ai := NewAtomicInt64(0)
+
+	if ai.IncrementAndGet() != 1 {
+		t.Error("IncrementAndGet should return 1")
+	}
+
+	if ai.DecrementAndGet() != 0 {
+		t.Error("DecrementAndGet should return 0")
+	}
+
+	if ai.GetAndAdd(5) != 0 {
+		t.Error("GetAndAdd should return 0")
+	}
+
+	if ai.AddAndGet(5) != 10 {
+		t.Error("AddAndGet should return 10")
+	}
+
+	if ai.GetAndSet(20) != 10 {
+		t.Error("GetAndSet should return 10")
+	}
+
+	if !ai.CompareAndSet(20, 30) {
+		t.Error("CompareAndSet should succeed when expectation is met")
+	}
+
+	if ai.CompareAndSet(20, 40) {
+		t.Error("CompareAndSet should fail when expectation is not met")
+	}
+
+	if ai.Get() != 30 {
+		t.Error("Get should return 30")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+)
+
+// mutexInt is the old mutex-guarded implementation, kept here only to
+// benchmark against the lock-free AtomicInt in int.go.
+type mutexInt struct {
+	val   int
+	mutex sync.RWMutex
+}
+
+func (mi *mutexInt) AddAndGet(delta int) int {
+	mi.mutex.Lock()
+	defer mi.mutex.Unlock()
+	mi.val += delta
+	return mi.val
+}
+
+func (mi *mutexInt) Get() int {
+	mi.mutex.RLock()
+	defer mi.mutex.RUnlock()
+	return mi.val
+}
+
+func TestAtomicInt(t *testing.T) {
+	ai := NewAtomicInt(0)
+
+	if ai.IncrementAndGet() != 1 {
+		t.Error("IncrementAndGet should return 1")
+	}
+
+	if ai.DecrementAndGet() != 0 {
+		t.Error("DecrementAndGet should return 0")
+	}
+
+	if ai.GetAndIncrement() != 0 {
+		t.Error("GetAndIncrement should return 0")
+	}
+
+	if ai.GetAndDecrement() != 1 {
+		t.Error("GetAndDecrement should return 1")
+	}
+
+	if ai.GetAndAdd(5) != 0 {
+		t.Error("GetAndAdd should return 0")
+	}
+
+	if ai.AddAndGet(5) != 10 {
+		t.Error("AddAndGet should return 10")
+	}
+
+	if ai.GetAndSet(20) != 10 {
+		t.Error("GetAndSet should return 10")
+	}
+
+	if !ai.CompareAndSet(20, 30) {
+		t.Error("CompareAndSet should succeed when expectation is met")
+	}
+
+	if ai.CompareAndSet(20, 40) {
+		t.Error("CompareAndSet should fail when expectation is not met")
+	}
+
+	if ai.Get() != 30 {
+		t.Error("Get should return 30")
+	}
+}
+
+func BenchmarkAtomicInt_AddAndGet(b *testing.B) {
+	ai := NewAtomicInt(0)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ai.AddAndGet(1)
+		}
+	})
+}
+
+func BenchmarkMutexInt_AddAndGet(b *testing.B) {
+	mi := &mutexInt{}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			mi.AddAndGet(1)
+		}
+	})
+}
+
+func BenchmarkAtomicInt_Get(b *testing.B) {
+	ai := NewAtomicInt(42)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ai.Get()
+		}
+	})
+}
+
+func BenchmarkMutexInt_Get(b *testing.B) {
+	mi := &mutexInt{val: 42}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			mi.Get()
+		}
+	})
+}
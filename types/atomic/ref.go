@@ -0,0 +1,77 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// refBox wraps a reference value so that atomic.Value always sees the same
+// concrete type, even when the reference itself is nil; atomic.Value.Store
+// panics on a bare nil interface, so nil values must always go in a box.
+type refBox struct {
+	v interface{}
+}
+
+// AtomicRef implements an arbitrary reference value with atomic semantics,
+// allowing things like the cluster's partition map or node list to be
+// swapped without readers having to hold the cluster mutex.
+type AtomicRef struct {
+	val atomic.Value
+
+	// writeMutex serializes all writers (Set and CompareAndSet) so that a
+	// plain Set can't interleave between CompareAndSet's load and store;
+	// reads via Get remain lock-free.
+	writeMutex sync.Mutex
+}
+
+// NewAtomicRef generates a new AtomicRef instance.
+func NewAtomicRef(value interface{}) *AtomicRef {
+	ar := &AtomicRef{}
+	ar.val.Store(&refBox{v: value})
+	return ar
+}
+
+// Get atomically retrieves the current value.
+func (ar *AtomicRef) Get() interface{} {
+	return ar.val.Load().(*refBox).v
+}
+
+// Set atomically sets the current value to the given value.
+func (ar *AtomicRef) Set(newValue interface{}) {
+	ar.writeMutex.Lock()
+	defer ar.writeMutex.Unlock()
+
+	ar.val.Store(&refBox{v: newValue})
+}
+
+// CompareAndSet atomically sets the value to the given updated value if the current value == expected value.
+// Returns true if the expectation was met.
+//
+// Equality is checked with reflect.DeepEqual rather than ==, so that
+// uncomparable values (maps, slices) such as a partition map can be used
+// without panicking; == would panic comparing those types.
+func (ar *AtomicRef) CompareAndSet(old interface{}, new interface{}) bool {
+	ar.writeMutex.Lock()
+	defer ar.writeMutex.Unlock()
+
+	if !reflect.DeepEqual(ar.val.Load().(*refBox).v, old) {
+		return false
+	}
+	ar.val.Store(&refBox{v: new})
+	return true
+}
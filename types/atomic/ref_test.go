@@ -0,0 +1,102 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package atomic
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAtomicRef(t *testing.T) {
+	type node struct{ name string }
+
+	n1 := &node{name: "n1"}
+	n2 := &node{name: "n2"}
+
+	ar := NewAtomicRef(n1)
+
+	if ar.Get().(*node) != n1 {
+		t.Error("Get should return n1")
+	}
+
+	if !ar.CompareAndSet(n1, n2) {
+		t.Error("CompareAndSet should succeed when expectation is met")
+	}
+
+	if ar.Get().(*node) != n2 {
+		t.Error("Get should return n2")
+	}
+
+	if ar.CompareAndSet(n1, n2) {
+		t.Error("CompareAndSet should fail when expectation is not met")
+	}
+
+	ar.Set(nil)
+	if ar.Get() != nil {
+		t.Error("Get should return nil")
+	}
+}
+
+// TestAtomicRefCompareAndSetUncomparable makes sure CompareAndSet doesn't
+// panic for uncomparable values, such as the partition maps this type is
+// meant to hold, where == would panic.
+func TestAtomicRefCompareAndSetUncomparable(t *testing.T) {
+	m1 := map[string]int{"a": 1}
+	m2 := map[string]int{"a": 2}
+
+	ar := NewAtomicRef(m1)
+
+	if !ar.CompareAndSet(m1, m2) {
+		t.Error("CompareAndSet should succeed when the map contents match")
+	}
+
+	if ar.CompareAndSet(m1, m2) {
+		t.Error("CompareAndSet should fail when the map contents no longer match")
+	}
+}
+
+// TestAtomicRefCompareAndSetRetryLoopUnderContention exercises
+// CompareAndSet concurrently the way the cluster would: many goroutines
+// racing a CAS retry loop to increment a single reference. Every increment
+// is reflected only if CompareAndSet's load, comparison and store are
+// serialized by writeMutex, so that two calls can't both succeed against
+// the same old value.
+func TestAtomicRefCompareAndSetRetryLoopUnderContention(t *testing.T) {
+	const goroutines = 50
+	const incrementsPerGoroutine = 200
+
+	ar := NewAtomicRef(0)
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < incrementsPerGoroutine; i++ {
+				for {
+					old := ar.Get().(int)
+					if ar.CompareAndSet(old, old+1) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if want := goroutines * incrementsPerGoroutine; ar.Get().(int) != want {
+		t.Errorf("expected %d successful increments to be reflected, got %d", want, ar.Get().(int))
+	}
+}